@@ -10,10 +10,22 @@ import (
 	"path/filepath"
 )
 
-
 type ChangelogEntry struct {
 	Type        ChangelogEntryType
 	Description string
+
+	// IssueLink is required on NEW_FEATURE entries; it should link to the issue or PR describing
+	// the feature.
+	IssueLink string `json:"issueLink,omitempty"`
+	// Migration is required on BREAKING_CHANGE entries; it should describe the steps users need to
+	// take to adopt the change.
+	Migration string `json:"migration,omitempty"`
+	// DependencyOwner, DependencyRepo, DependencyOldVersion, and DependencyNewVersion are all
+	// required on DEPENDENCY_BUMP entries.
+	DependencyOwner      string `json:"dependencyOwner,omitempty"`
+	DependencyRepo       string `json:"dependencyRepo,omitempty"`
+	DependencyOldVersion string `json:"dependencyOldVersion,omitempty"`
+	DependencyNewVersion string `json:"dependencyNewVersion,omitempty"`
 }
 
 type ChangelogFile struct {
@@ -21,14 +33,14 @@ type ChangelogFile struct {
 }
 
 type Changelog struct {
-	Files []ChangelogFile
+	Files   []ChangelogFile
 	Summary string
 	Version string
 }
 
 type RawChangelogFile struct {
 	Filename string
-	Bytes []byte
+	Bytes    []byte
 }
 
 const (
@@ -74,7 +86,7 @@ func GetProposedTagLocal(latestTag, changelogParentPath string) (string, error)
 		}
 	}
 	if proposedVersion == "" {
-		return "",  errors.Errorf("No version greater than %s found", latestTag)
+		return "", errors.Errorf("No version greater than %s found", latestTag)
 	}
 	return proposedVersion, nil
 }
@@ -91,4 +103,4 @@ func ReadChangelogFile(path string) (*Changelog, error) {
 	}
 
 	return &changelog, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,185 @@
+package changelogutils
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// VersionBump is the semver component a set of changelog entries requires bumping.
+type VersionBump int
+
+const (
+	PatchBump VersionBump = iota
+	MinorBump
+	MajorBump
+)
+
+func (b VersionBump) String() string {
+	switch b {
+	case MajorBump:
+		return "major"
+	case MinorBump:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+func entryTypeBump(t ChangelogEntryType) VersionBump {
+	switch t {
+	case BREAKING_CHANGE:
+		return MajorBump
+	case NEW_FEATURE:
+		return MinorBump
+	default:
+		return PatchBump
+	}
+}
+
+// ComputeVersionBump derives the semver bump implied by the highest-severity entry across
+// entries, e.g. a single BREAKING_CHANGE entry forces a major bump even if every other entry is a
+// patch-level fix.
+func ComputeVersionBump(entries []ChangelogEntry) VersionBump {
+	bump := PatchBump
+	for _, entry := range entries {
+		if b := entryTypeBump(entry.Type); b > bump {
+			bump = b
+		}
+	}
+	return bump
+}
+
+// PrepareReleaseResult is the output of PrepareRelease.
+type PrepareReleaseResult struct {
+	Version  string
+	Markdown string
+	Errors   []error
+}
+
+// PrepareRelease validates every changelog entry under path, renders release-notes markdown, and
+// cross-checks the version bump implied by the entries against GetProposedTagLocal's proposed
+// directory. It collects every problem it finds into Errors rather than failing on the first one,
+// so a single CI run can report everything wrong with a release at once. Failures to look up the
+// latest tag or to read the proposed version's changelog files are returned as the error instead.
+func PrepareRelease(ctx context.Context, owner, repo, path string) (*PrepareReleaseResult, error) {
+	latestTag, err := GetLatestTag(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	proposedVersion, err := GetProposedTagLocal(latestTag, path)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog, err := readChangelogDir(path, proposedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PrepareReleaseResult{Version: proposedVersion}
+
+	validator := NewValidator()
+	var allEntries []ChangelogEntry
+	for _, file := range changelog.Files {
+		for _, entry := range file.Entries {
+			if err := validator.ValidateEntry(entry); err != nil {
+				result.Errors = append(result.Errors, err)
+			}
+		}
+		allEntries = append(allEntries, file.Entries...)
+	}
+	if len(allEntries) == 0 {
+		// with no entries to validate, ValidateChangelog reports only the missing-entries error
+		result.Errors = append(result.Errors, validator.ValidateChangelog(changelog))
+	}
+	bump := ComputeVersionBump(allEntries)
+	expected, err := expectedBump(latestTag, proposedVersion)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+	} else if expected != bump {
+		result.Errors = append(result.Errors, errors.Errorf(
+			"changelog entries imply a %s bump but proposed version %s is a %s bump from %s", bump, proposedVersion, expected, latestTag))
+	}
+
+	result.Markdown = NewRenderer().RenderMarkdown(owner, repo, changelog)
+	return result, nil
+}
+
+// readChangelogDir reads every yaml file in the changelog/<version> subdirectory of
+// changelogParentPath into a single Changelog.
+func readChangelogDir(changelogParentPath, version string) (*Changelog, error) {
+	versionDir := filepath.Join(changelogParentPath, ChangelogDirectory, version)
+	fileInfos, err := ioutil.ReadDir(versionDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading changelog directory for version %s", version)
+	}
+
+	changelog := &Changelog{Version: version}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		file, err := readChangelogFile(filepath.Join(versionDir, fileInfo.Name()))
+		if err != nil {
+			return nil, err
+		}
+		changelog.Files = append(changelog.Files, *file)
+	}
+	return changelog, nil
+}
+
+func readChangelogFile(path string) (*ChangelogFile, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading changelog file: %s", path)
+	}
+	var file ChangelogFile
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return nil, errors.Wrapf(err, "failed parsing changelog file: %s", path)
+	}
+	return &file, nil
+}
+
+// expectedBump compares the major/minor components of proposedVersion against latestTag to derive
+// the semver bump the proposed directory name itself represents.
+func expectedBump(latestTag, proposedVersion string) (VersionBump, error) {
+	latestMajor, latestMinor, _, err := parseSemver(latestTag)
+	if err != nil {
+		return PatchBump, err
+	}
+	proposedMajor, proposedMinor, _, err := parseSemver(proposedVersion)
+	if err != nil {
+		return PatchBump, err
+	}
+	switch {
+	case proposedMajor > latestMajor:
+		return MajorBump, nil
+	case proposedMinor > latestMinor:
+		return MinorBump, nil
+	default:
+		return PatchBump, nil
+	}
+}
+
+func parseSemver(tag string) (major, minor, patch int, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(tag, "v"), ".", 3)
+	if len(parts) != 3 {
+		return 0, 0, 0, errors.Errorf("invalid semver tag %s", tag)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, 0, errors.Wrapf(err, "invalid semver tag %s", tag)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, 0, errors.Wrapf(err, "invalid semver tag %s", tag)
+	}
+	if patch, err = strconv.Atoi(parts[2]); err != nil {
+		return 0, 0, 0, errors.Wrapf(err, "invalid semver tag %s", tag)
+	}
+	return major, minor, patch, nil
+}
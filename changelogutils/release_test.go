@@ -0,0 +1,55 @@
+package changelogutils
+
+import "testing"
+
+func TestComputeVersionBump(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []ChangelogEntry
+		want    VersionBump
+	}{
+		{"empty", nil, PatchBump},
+		{"only fixes", []ChangelogEntry{{Type: FIX}, {Type: NON_USER_FACING}}, PatchBump},
+		{"includes a feature", []ChangelogEntry{{Type: FIX}, {Type: NEW_FEATURE}}, MinorBump},
+		{"includes a breaking change", []ChangelogEntry{{Type: NEW_FEATURE}, {Type: BREAKING_CHANGE}}, MajorBump},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ComputeVersionBump(c.entries); got != c.want {
+				t.Errorf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestExpectedBump(t *testing.T) {
+	cases := []struct {
+		name       string
+		latest     string
+		proposed   string
+		want       VersionBump
+		expectFail bool
+	}{
+		{"patch", "v1.2.3", "v1.2.4", PatchBump, false},
+		{"minor", "v1.2.3", "v1.3.0", MinorBump, false},
+		{"major", "v1.2.3", "v2.0.0", MajorBump, false},
+		{"invalid tag", "not-a-version", "v1.2.4", PatchBump, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := expectedBump(c.latest, c.proposed)
+			if c.expectFail {
+				if err == nil {
+					t.Fatal("expected an error for an invalid semver tag")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
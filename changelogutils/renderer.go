@@ -0,0 +1,94 @@
+package changelogutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderOrder controls the section order of the rendered markdown, highest-impact changes first.
+var renderOrder = []ChangelogEntryType{
+	BREAKING_CHANGE,
+	NEW_FEATURE,
+	FIX,
+	DEPENDENCY_BUMP,
+	HELM_VALUES_CHANGED,
+	NON_USER_FACING,
+}
+
+var sectionTitles = map[ChangelogEntryType]string{
+	BREAKING_CHANGE:     "Breaking Changes",
+	NEW_FEATURE:         "New Features",
+	FIX:                 "Fixes",
+	DEPENDENCY_BUMP:     "Dependency Bumps",
+	HELM_VALUES_CHANGED: "Helm Values Changed",
+	NON_USER_FACING:     "Non-User-Facing Changes",
+}
+
+// Renderer produces GitHub-flavored release-notes markdown from a Changelog.
+type Renderer struct{}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// RenderMarkdown groups changelog's entries by type and renders them in a stable section order,
+// suitable for pasting directly into a GitHub release for owner/repo.
+func (r *Renderer) RenderMarkdown(owner, repo string, changelog *Changelog) string {
+	grouped := map[ChangelogEntryType][]ChangelogEntry{}
+	for _, file := range changelog.Files {
+		for _, entry := range file.Entries {
+			grouped[entry.Type] = append(grouped[entry.Type], entry)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n", changelog.Version)
+	if changelog.Summary != "" {
+		fmt.Fprintf(&sb, "\n%s\n", changelog.Summary)
+	}
+	for _, entryType := range renderOrder {
+		entries := grouped[entryType]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", sectionTitles[entryType])
+		for _, entry := range entries {
+			sb.WriteString(renderEntry(entry, issueURL(owner, repo, entry.IssueLink)))
+		}
+	}
+	return sb.String()
+}
+
+// issueURL resolves issueLink to a full GitHub URL. Entries may reference an issue either as a
+// bare number ("123") or as an already-complete URL.
+func issueURL(owner, repo, issueLink string) string {
+	if issueLink == "" {
+		return ""
+	}
+	if strings.HasPrefix(issueLink, "http://") || strings.HasPrefix(issueLink, "https://") {
+		return issueLink
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%s", owner, repo, issueLink)
+}
+
+func renderEntry(entry ChangelogEntry, issueLink string) string {
+	switch entry.Type {
+	case NEW_FEATURE:
+		line := fmt.Sprintf("- %s", entry.Description)
+		if issueLink != "" {
+			line += fmt.Sprintf(" ([issue](%s))", issueLink)
+		}
+		return line + "\n"
+	case BREAKING_CHANGE:
+		line := fmt.Sprintf("- %s", entry.Description)
+		if entry.Migration != "" {
+			line += fmt.Sprintf("\n  - Migration: %s", entry.Migration)
+		}
+		return line + "\n"
+	case DEPENDENCY_BUMP:
+		return fmt.Sprintf("- Bump %s/%s from %s to %s\n",
+			entry.DependencyOwner, entry.DependencyRepo, entry.DependencyOldVersion, entry.DependencyNewVersion)
+	default:
+		return fmt.Sprintf("- %s\n", entry.Description)
+	}
+}
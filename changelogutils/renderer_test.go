@@ -0,0 +1,51 @@
+package changelogutils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown_GroupsAndOrdersSections(t *testing.T) {
+	changelog := &Changelog{
+		Version: "v1.2.0",
+		Summary: "a great release",
+		Files: []ChangelogFile{
+			{Entries: []ChangelogEntry{
+				{Type: FIX, Description: "fixed a bug"},
+				{Type: NEW_FEATURE, Description: "added a thing", IssueLink: "123"},
+				{Type: BREAKING_CHANGE, Description: "removed a thing", Migration: "do the migration"},
+			}},
+		},
+	}
+
+	markdown := NewRenderer().RenderMarkdown("solo-io", "go-utils", changelog)
+
+	breakingIdx := strings.Index(markdown, "Breaking Changes")
+	featureIdx := strings.Index(markdown, "New Features")
+	fixIdx := strings.Index(markdown, "Fixes")
+	if breakingIdx == -1 || featureIdx == -1 || fixIdx == -1 {
+		t.Fatalf("expected all three sections to be rendered, got:\n%s", markdown)
+	}
+	if !(breakingIdx < featureIdx && featureIdx < fixIdx) {
+		t.Fatalf("expected sections in Breaking -> New Features -> Fixes order, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "https://github.com/solo-io/go-utils/issues/123") {
+		t.Fatalf("expected the bare issue number to be resolved to a full URL, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "Migration: do the migration") {
+		t.Fatalf("expected the migration note to be rendered, got:\n%s", markdown)
+	}
+}
+
+func TestRenderMarkdown_OmitsEmptySections(t *testing.T) {
+	changelog := &Changelog{
+		Version: "v1.2.0",
+		Files:   []ChangelogFile{{Entries: []ChangelogEntry{{Type: FIX, Description: "fixed a bug"}}}},
+	}
+
+	markdown := NewRenderer().RenderMarkdown("solo-io", "go-utils", changelog)
+
+	if strings.Contains(markdown, "Breaking Changes") {
+		t.Fatalf("expected no Breaking Changes section when there are no such entries, got:\n%s", markdown)
+	}
+}
@@ -0,0 +1,62 @@
+package changelogutils
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Validator enforces the schema rules for a ChangelogEntry: every entry needs a non-empty
+// description, and certain entry types require additional fields to be set.
+type Validator struct{}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+func (v *Validator) ValidateEntry(entry ChangelogEntry) error {
+	if strings.TrimSpace(entry.Description) == "" {
+		return errors.Errorf("changelog entry of type %v must have a non-empty description", entry.Type)
+	}
+	switch entry.Type {
+	case NEW_FEATURE:
+		if entry.IssueLink == "" {
+			return errors.Errorf("changelog entries of type NEW_FEATURE must set issueLink")
+		}
+	case BREAKING_CHANGE:
+		if entry.Migration == "" {
+			return errors.Errorf("changelog entries of type BREAKING_CHANGE must set migration")
+		}
+	case DEPENDENCY_BUMP:
+		if entry.DependencyOwner == "" || entry.DependencyRepo == "" || entry.DependencyOldVersion == "" || entry.DependencyNewVersion == "" {
+			return errors.Errorf("changelog entries of type DEPENDENCY_BUMP must set dependencyOwner, dependencyRepo, dependencyOldVersion, and dependencyNewVersion")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) ValidateFile(file ChangelogFile) error {
+	for _, entry := range file.Entries {
+		if err := v.ValidateEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateChangelog validates every entry in changelog, and additionally fails if the proposed
+// version has no entries at all - whether because it has no files, or because every file it does
+// have is empty.
+func (v *Validator) ValidateChangelog(changelog *Changelog) error {
+	entryCount := 0
+	for _, file := range changelog.Files {
+		entryCount += len(file.Entries)
+		if err := v.ValidateFile(file); err != nil {
+			return err
+		}
+	}
+	if entryCount == 0 {
+		return errors.Errorf("no changelog entries found for proposed version %s", changelog.Version)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package changelogutils
+
+import "testing"
+
+func TestValidateEntry_RequiresDescription(t *testing.T) {
+	v := NewValidator()
+	if err := v.ValidateEntry(ChangelogEntry{Type: FIX, Description: "  "}); err == nil {
+		t.Fatal("expected an error for an empty description")
+	}
+}
+
+func TestValidateEntry_NewFeatureRequiresIssueLink(t *testing.T) {
+	v := NewValidator()
+	entry := ChangelogEntry{Type: NEW_FEATURE, Description: "added a thing"}
+	if err := v.ValidateEntry(entry); err == nil {
+		t.Fatal("expected an error when issueLink is missing")
+	}
+	entry.IssueLink = "123"
+	if err := v.ValidateEntry(entry); err != nil {
+		t.Fatalf("unexpected error once issueLink is set: %v", err)
+	}
+}
+
+func TestValidateEntry_BreakingChangeRequiresMigration(t *testing.T) {
+	v := NewValidator()
+	entry := ChangelogEntry{Type: BREAKING_CHANGE, Description: "removed a thing"}
+	if err := v.ValidateEntry(entry); err == nil {
+		t.Fatal("expected an error when migration is missing")
+	}
+	entry.Migration = "run the migration script"
+	if err := v.ValidateEntry(entry); err != nil {
+		t.Fatalf("unexpected error once migration is set: %v", err)
+	}
+}
+
+func TestValidateEntry_DependencyBumpRequiresAllFields(t *testing.T) {
+	v := NewValidator()
+	entry := ChangelogEntry{Type: DEPENDENCY_BUMP, Description: "bump a dep"}
+	if err := v.ValidateEntry(entry); err == nil {
+		t.Fatal("expected an error when dependency fields are missing")
+	}
+	entry.DependencyOwner, entry.DependencyRepo = "solo-io", "go-utils"
+	entry.DependencyOldVersion, entry.DependencyNewVersion = "v1.0.0", "v1.1.0"
+	if err := v.ValidateEntry(entry); err != nil {
+		t.Fatalf("unexpected error once all dependency fields are set: %v", err)
+	}
+}
+
+func TestValidateChangelog_FailsOnNoFiles(t *testing.T) {
+	v := NewValidator()
+	if err := v.ValidateChangelog(&Changelog{Version: "v1.2.0"}); err == nil {
+		t.Fatal("expected an error for a changelog with no files")
+	}
+}
+
+func TestValidateChangelog_FailsOnFilesWithNoEntries(t *testing.T) {
+	v := NewValidator()
+	changelog := &Changelog{
+		Version: "v1.2.0",
+		Files:   []ChangelogFile{{Entries: nil}, {Entries: []ChangelogEntry{}}},
+	}
+	if err := v.ValidateChangelog(changelog); err == nil {
+		t.Fatal("expected an error for a changelog whose files have zero entries between them")
+	}
+}
+
+func TestValidateChangelog_PassesWithAtLeastOneEntry(t *testing.T) {
+	v := NewValidator()
+	changelog := &Changelog{
+		Version: "v1.2.0",
+		Files: []ChangelogFile{
+			{Entries: nil},
+			{Entries: []ChangelogEntry{{Type: FIX, Description: "fixed a bug"}}},
+		},
+	}
+	if err := v.ValidateChangelog(changelog); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package debugutils
 
 import (
+	"context"
 	"time"
 
 	"github.com/solo-io/go-utils/installutils/helmchart"
@@ -15,6 +16,7 @@ import (
 type LogCollector interface {
 	GetLogRequests(resources kuberesource.UnstructuredResources) ([]*LogsRequest, error)
 	SaveLogs(client StorageClient, location string, requests []*LogsRequest) error
+	StreamLogs(ctx context.Context, client StorageClient, location string, requests []*LogsRequest, opts ...StreamLogsOption) (<-chan LogEvent, error)
 }
 
 type logCollector struct {
@@ -85,7 +87,7 @@ var (
 	}
 	LogsSince = func(since time.Time) LogRequestOptions {
 		return func(options *corev1.PodLogOptions) {
-			options.SinceTime = &metav1.Time{Time: since,}
+			options.SinceTime = &metav1.Time{Time: since}
 		}
 	}
 )
@@ -0,0 +1,147 @@
+package debugutils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/go-utils/errors"
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// ownerRefPodFinder discovers pods that are transitively owned by the resources passed to GetPods,
+// by walking Kubernetes OwnerReferences instead of matching selector labels. This covers pods
+// owned by CRDs, or by Deployments whose ReplicaSet's pod-template-hash label has diverged from
+// the parent's own selector.
+type ownerRefPodFinder struct {
+	clientset     corev1client.CoreV1Interface
+	dynamicClient dynamic.Interface
+}
+
+func NewOwnerRefPodFinder(clientset corev1client.CoreV1Interface, dynamicClient dynamic.Interface) *ownerRefPodFinder {
+	return &ownerRefPodFinder{clientset: clientset, dynamicClient: dynamicClient}
+}
+
+func (o *ownerRefPodFinder) GetPods(resources kuberesource.UnstructuredResources) ([]*corev1.PodList, error) {
+	var result []*corev1.PodList
+	for _, resource := range resources {
+		namespace := resource.GetNamespace()
+		pods, err := o.clientset.Pods(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing pods in namespace %s", namespace)
+		}
+
+		matched := &corev1.PodList{}
+		for _, pod := range pods.Items {
+			owned, err := o.isOwnedBy(pod.OwnerReferences, namespace, resource.GetUID())
+			if err != nil {
+				return nil, err
+			}
+			if owned {
+				matched.Items = append(matched.Items, pod)
+			}
+		}
+		if len(matched.Items) > 0 {
+			result = append(result, matched)
+		}
+	}
+	return result, nil
+}
+
+// isOwnedBy walks refs (e.g. Pod -> ReplicaSet -> Deployment, Pod -> Job -> CronJob, Pod ->
+// StatefulSet, or an arbitrary CRD) looking for targetUID, resolving each intermediate owner via
+// the dynamic client.
+func (o *ownerRefPodFinder) isOwnedBy(refs []metav1.OwnerReference, namespace string, targetUID types.UID) (bool, error) {
+	for _, ref := range refs {
+		if ref.UID == targetUID {
+			return true, nil
+		}
+		gvr := gvrFromOwnerRef(ref)
+		owner, err := o.dynamicClient.Resource(gvr).Namespace(namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			// the owner may already be gone (e.g. a completed Job's pods can outlive it briefly), or
+			// our best-effort GVR guess for this Kind may be wrong (see gvrFromOwnerRef); either way
+			// this branch of the chain can't be resolved further, but it's worth a log since the
+			// latter case means we're silently under-reporting owned pods.
+			contextutils.LoggerFrom(context.Background()).Debugw("unable to resolve owner reference while walking pod ownership chain",
+				zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.Stringer("gvr", gvr), zap.Error(err))
+			continue
+		}
+		owned, err := o.isOwnedBy(owner.GetOwnerReferences(), namespace, targetUID)
+		if err != nil {
+			return false, err
+		}
+		if owned {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// gvrFromOwnerRef derives the GroupVersionResource for an OwnerReference without a RESTMapper, by
+// lowercasing and pluralizing the Kind. This covers the common case (and every built-in controller
+// kind referenced in owner chains); callers targeting a CRD with an irregular plural should resolve
+// it themselves and wrap the result in a CompositePodFinder instead. A guess that's wrong for a
+// given CRD just means that branch of the owner chain can't be resolved - isOwnedBy logs and moves
+// on rather than failing the whole lookup.
+func gvrFromOwnerRef(ref metav1.OwnerReference) schema.GroupVersionResource {
+	gv, _ := schema.ParseGroupVersion(ref.APIVersion)
+	return gv.WithResource(pluralizeKind(ref.Kind))
+}
+
+// pluralizeKind lowercases and pluralizes kind following the same handful of English pluralization
+// rules the Kubernetes API itself relies on for built-in resource names (Policy -> policies,
+// Ingress -> ingresses, ConfigMap -> configmaps).
+func pluralizeKind(kind string) string {
+	lower := strings.ToLower(kind)
+	switch {
+	case len(lower) >= 2 && strings.HasSuffix(lower, "y") && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return lower[:len(lower)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return lower + "es"
+	default:
+		return lower + "s"
+	}
+}
+
+// CompositePodFinder unions the results of several PodFinders, de-duplicating pods by UID. This
+// lets callers combine label-based and owner-ref-based discovery so operators that install CRDs
+// whose managed pods have no predictable label scheme are still covered.
+type CompositePodFinder struct {
+	finders []PodFinder
+}
+
+func NewCompositePodFinder(finders ...PodFinder) *CompositePodFinder {
+	return &CompositePodFinder{finders: finders}
+}
+
+func (c *CompositePodFinder) GetPods(resources kuberesource.UnstructuredResources) ([]*corev1.PodList, error) {
+	seen := map[types.UID]bool{}
+	merged := &corev1.PodList{}
+	for _, finder := range c.finders {
+		podLists, err := finder.GetPods(resources)
+		if err != nil {
+			return nil, err
+		}
+		for _, podList := range podLists {
+			for _, pod := range podList.Items {
+				if seen[pod.UID] {
+					continue
+				}
+				seen[pod.UID] = true
+				merged.Items = append(merged.Items, pod)
+			}
+		}
+	}
+	if len(merged.Items) == 0 {
+		return nil, nil
+	}
+	return []*corev1.PodList{merged}, nil
+}
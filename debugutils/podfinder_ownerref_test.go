@@ -0,0 +1,83 @@
+package debugutils
+
+import (
+	"testing"
+
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPluralizeKind(t *testing.T) {
+	cases := []struct {
+		kind string
+		want string
+	}{
+		{"Deployment", "deployments"},
+		{"ReplicaSet", "replicasets"},
+		{"Ingress", "ingresses"},
+		{"NetworkPolicy", "networkpolicies"},
+		{"Gateway", "gateways"},
+		{"VirtualService", "virtualservices"},
+		{"Y", "ys"}, // single-character Kind ending in "y" must not panic on the suffix slice
+		{"y", "ys"},
+	}
+	for _, c := range cases {
+		if got := pluralizeKind(c.kind); got != c.want {
+			t.Errorf("pluralizeKind(%q) = %q, want %q", c.kind, got, c.want)
+		}
+	}
+}
+
+type fakePodFinder struct {
+	podLists []*corev1.PodList
+	err      error
+}
+
+func (f *fakePodFinder) GetPods(resources kuberesource.UnstructuredResources) ([]*corev1.PodList, error) {
+	return f.podLists, f.err
+}
+
+func TestCompositePodFinder_DeduplicatesByUID(t *testing.T) {
+	shared := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "shared", UID: types.UID("shared-uid")}}
+	onlyA := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "only-a", UID: types.UID("a-uid")}}
+	onlyB := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "only-b", UID: types.UID("b-uid")}}
+
+	a := &fakePodFinder{podLists: []*corev1.PodList{{Items: []corev1.Pod{shared, onlyA}}}}
+	b := &fakePodFinder{podLists: []*corev1.PodList{{Items: []corev1.Pod{shared, onlyB}}}}
+
+	composite := NewCompositePodFinder(a, b)
+	result, err := composite.GetPods(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected a single merged PodList, got %d", len(result))
+	}
+
+	seen := map[types.UID]bool{}
+	for _, pod := range result[0].Items {
+		if seen[pod.UID] {
+			t.Fatalf("pod %s (uid %s) appeared more than once in the merged result", pod.Name, pod.UID)
+		}
+		seen[pod.UID] = true
+	}
+	if len(result[0].Items) != 3 {
+		t.Fatalf("expected 3 deduplicated pods, got %d", len(result[0].Items))
+	}
+}
+
+func TestCompositePodFinder_PropagatesFinderError(t *testing.T) {
+	failing := &fakePodFinder{err: errTestFinder}
+	composite := NewCompositePodFinder(failing)
+	if _, err := composite.GetPods(nil); err != errTestFinder {
+		t.Fatalf("expected the underlying finder's error to propagate, got %v", err)
+	}
+}
+
+var errTestFinder = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,338 @@
+package debugutils
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/solo-io/go-utils/contextutils"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// DefaultRotateBytes is the rotation threshold used when no RotateBytes option is given.
+	DefaultRotateBytes    = 50 * 1024 * 1024 // 50MB
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// LogEvent is a single line read from a pod/container log stream, published by StreamLogs for
+// callers that want to consume logs programmatically instead of only through storage.
+type LogEvent struct {
+	Pod       string
+	Container string
+	Line      string
+	Timestamp time.Time
+}
+
+type streamLogsOptions struct {
+	additionalSinks []StorageClient
+	rotateBytes     int64
+	rotateInterval  time.Duration
+}
+
+// StreamLogsOption configures StreamLogs.
+type StreamLogsOption func(*streamLogsOptions)
+
+// RotateBytes rotates a pod/container's output object once it has written at least n bytes.
+func RotateBytes(n int64) StreamLogsOption {
+	return func(o *streamLogsOptions) {
+		o.rotateBytes = n
+	}
+}
+
+// RotateInterval rotates a pod/container's output object every d, regardless of size.
+func RotateInterval(d time.Duration) StreamLogsOption {
+	return func(o *streamLogsOptions) {
+		o.rotateInterval = d
+	}
+}
+
+// WithAdditionalSinks fans every streamed line out to the given sinks, in addition to the primary
+// StorageClient passed to StreamLogs. Each sink is written to independently, so a failure on one
+// sink does not interrupt the others.
+func WithAdditionalSinks(sinks ...StorageClient) StreamLogsOption {
+	return func(o *streamLogsOptions) {
+		o.additionalSinks = append(o.additionalSinks, sinks...)
+	}
+}
+
+// StreamLogs continuously tails the given log requests rather than reading them once like SaveLogs.
+// It is meant for long-running FollowLogs sessions: a stream that hits EOF (the pod restarted, or
+// the apiserver closed the connection) is re-opened with exponential backoff until ctx is
+// cancelled, output objects are rotated by size and/or duration so a long session doesn't produce
+// one unbounded blob, and every line read is also offered on the returned channel so callers can
+// consume logs without going through storage at all. The channel is unbuffered and a line is
+// dropped from it when no receiver is ready at that moment. The returned channel is closed once every
+// request's goroutine has returned, which only happens when ctx is done.
+func (lc *logCollector) StreamLogs(ctx context.Context, storageClient StorageClient, location string, requests []*LogsRequest, opts ...StreamLogsOption) (<-chan LogEvent, error) {
+	options := &streamLogsOptions{
+		rotateBytes: DefaultRotateBytes,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	sinks := append([]StorageClient{storageClient}, options.additionalSinks...)
+	clientset := lc.logRequestBuilder.clientset
+
+	events := make(chan LogEvent)
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, request := range requests {
+		request := request
+		eg.Go(func() error {
+			streamWithRetry(egCtx, clientset, request, sinks, location, options, events)
+			return nil
+		})
+	}
+
+	go func() {
+		// errors are isolated per-request/per-sink and logged as they happen, so a failure on one
+		// pod never tears down the whole stream; Wait only ever blocks until ctx is done.
+		_ = eg.Wait()
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// streamWithRetry keeps re-opening request's log stream, with exponential backoff between
+// attempts, until ctx is cancelled. partCounter is shared across every reconnect so rotation
+// numbering stays monotonic for the life of the request, instead of restarting from the same part
+// number each time the stream reconnects. lastSeen tracks the timestamp of the last line read, so
+// that a reconnect resumes from there via LogsSince instead of re-fetching the whole log from the
+// start - clientset is needed to rebuild the request with that updated SinceTime.
+func streamWithRetry(ctx context.Context, clientset corev1client.CoreV1Interface, request *LogsRequest, sinks []StorageClient, location string, options *streamLogsOptions, events chan<- LogEvent) {
+	backoff := defaultInitialBackoff
+	partCounter := new(int)
+	var lastSeen *time.Time
+	for ctx.Err() == nil {
+		seen, err := streamOnce(ctx, clientset, request, lastSeen, sinks, location, options, partCounter, events)
+		if seen != nil {
+			lastSeen = seen
+		}
+		// the part streamOnce just finalized (whether it ever rotated internally or not) is done
+		// being written to; bump past it so a reconnect never reuses - and overwrites - that name.
+		*partCounter++
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			contextutils.LoggerFrom(ctx).Errorw("error streaming logs, retrying",
+				zap.String("resource", request.ResourceId()), zap.Error(err), zap.Duration("backoff", backoff))
+		} else if seen != nil {
+			// clean EOF after the stream delivered lines (e.g. the container restarted); start the
+			// backoff over
+			backoff = defaultInitialBackoff
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > defaultMaxBackoff {
+			backoff = defaultMaxBackoff
+		}
+	}
+}
+
+// streamOnce opens request's log stream until it hits EOF or ctx is cancelled, rotating the
+// destination object across sinks as configured and publishing every line to events. The stream is
+// always (re)built from scratch via clientset instead of reusing request.Request, so that since,
+// once non-nil, is honored - that's what keeps a reconnect from re-streaming everything the
+// container has ever logged. It returns the timestamp of the last line it read (nil if none were
+// read this attempt), for the caller to pass back in as since on the next attempt.
+func streamOnce(ctx context.Context, clientset corev1client.CoreV1Interface, request *LogsRequest, since *time.Time, sinks []StorageClient, location string, options *streamLogsOptions, partCounter *int, events chan<- LogEvent) (*time.Time, error) {
+	logOpts := &corev1.PodLogOptions{Follow: true, Container: request.Container, Timestamps: true}
+	if since != nil {
+		sinceTime := since.Add(time.Nanosecond) // strictly after the last line we already saw
+		logOpts.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	reader, err := clientset.Pods(request.PodMeta.Namespace).GetLogs(request.PodMeta.Name, logOpts).Stream()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	rot := newRotatingWriter(ctx, sinks, location, request.ResourceId(), partCounter, options)
+	defer rot.Close()
+
+	var lastSeen *time.Time
+	lines := newLineReader(reader)
+	for {
+		if ctx.Err() != nil {
+			return lastSeen, nil
+		}
+		rawLine, err := lines.ReadLine()
+		if rawLine != "" {
+			timestamp, line := splitTimestampedLine(rawLine)
+			lastSeen = &timestamp
+			rot.WriteLine(line)
+			// best-effort: a caller that only wants the storage fan-out and never drains this
+			// channel must not be able to stall delivery to the sinks above, so dropping the event
+			// here is preferable to blocking.
+			select {
+			case events <- LogEvent{Pod: request.PodMeta.Name, Container: request.Container, Line: line, Timestamp: timestamp}:
+			default:
+			}
+		}
+		if err != nil {
+			return lastSeen, nil // EOF or stream closed; caller decides whether to reconnect
+		}
+	}
+}
+
+// splitTimestampedLine splits a line read with PodLogOptions.Timestamps=true into its leading
+// RFC3339Nano timestamp and the original log content, so that timestamp can drive a later
+// reconnect's SinceTime without leaking into what's written to sinks/LogEvent. If rawLine doesn't
+// have a parseable timestamp prefix (unexpected, but not worth failing the stream over), it's
+// passed through unchanged and timestamped with the local read time instead.
+func splitTimestampedLine(rawLine string) (time.Time, string) {
+	tsStr, rest, found := strings.Cut(rawLine, " ")
+	if !found {
+		return time.Now(), rawLine
+	}
+	ts, err := time.Parse(time.RFC3339Nano, tsStr)
+	if err != nil {
+		return time.Now(), rawLine
+	}
+	return ts, rest
+}
+
+// sinkWriter pipes a rotatingWriter's lines into a single StorageClient. Once a write to it fails,
+// it's marked failed and silently skipped for the remainder of the part: a slow/broken sink must
+// never hold back delivery to the others, or force the source stream to reconnect.
+type sinkWriter struct {
+	sink   StorageClient
+	pw     *io.PipeWriter
+	failed int32
+}
+
+func (s *sinkWriter) isFailed() bool { return atomic.LoadInt32(&s.failed) == 1 }
+func (s *sinkWriter) markFailed()    { atomic.StoreInt32(&s.failed, 1) }
+
+// rotatingWriter fans a log stream out to one rotating StorageObject per sink, starting a new part
+// whenever the configured byte or duration threshold is crossed.
+type rotatingWriter struct {
+	ctx         context.Context
+	sinks       []StorageClient
+	location    string
+	resourceId  string
+	options     *streamLogsOptions
+	partCounter *int // shared with every rotatingWriter created for this request across reconnects
+	written     int64
+	opened      time.Time
+	sinkWriters []*sinkWriter
+	wg          sync.WaitGroup
+}
+
+func newRotatingWriter(ctx context.Context, sinks []StorageClient, location, resourceId string, partCounter *int, options *streamLogsOptions) *rotatingWriter {
+	rot := &rotatingWriter{ctx: ctx, sinks: sinks, location: location, resourceId: resourceId, options: options, partCounter: partCounter}
+	rot.open()
+	return rot
+}
+
+func (r *rotatingWriter) open() {
+	name := r.partName()
+	r.sinkWriters = nil
+	for _, sink := range r.sinks {
+		pr, pw := io.Pipe()
+		sw := &sinkWriter{sink: sink, pw: pw}
+		r.sinkWriters = append(r.sinkWriters, sw)
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			if err := sw.sink.Save(r.location, &StorageObject{Resource: pr, Name: name}); err != nil {
+				contextutils.LoggerFrom(r.ctx).Errorw("error writing log part to sink, dropping this sink for the rest of the part",
+					zap.String("resource", r.resourceId), zap.Int("part", *r.partCounter), zap.Error(err))
+				sw.markFailed()
+				pr.CloseWithError(err)
+				return
+			}
+			pr.Close()
+		}()
+	}
+	r.written = 0
+	r.opened = time.Now()
+}
+
+func (r *rotatingWriter) partName() string {
+	return fmt.Sprintf("%s-%06d", r.resourceId, *r.partCounter)
+}
+
+// WriteLine writes line to every sink that hasn't already failed. A write failure on one sink only
+// ever disables that sink for the rest of the part; it's never returned to the caller, so it can
+// never abort the source log stream.
+func (r *rotatingWriter) WriteLine(line string) {
+	if r.shouldRotate() {
+		r.closeCurrent()
+		*r.partCounter++
+		r.open()
+	}
+	payload := []byte(line + "\n")
+	for _, sw := range r.sinkWriters {
+		if sw.isFailed() {
+			continue
+		}
+		if _, err := sw.pw.Write(payload); err != nil {
+			contextutils.LoggerFrom(r.ctx).Errorw("error writing log line to sink, dropping this sink for the rest of the part",
+				zap.String("resource", r.resourceId), zap.Error(err))
+			sw.markFailed()
+			continue
+		}
+	}
+	r.written += int64(len(payload))
+}
+
+func (r *rotatingWriter) shouldRotate() bool {
+	if r.options.rotateBytes > 0 && r.written >= r.options.rotateBytes {
+		return true
+	}
+	if r.options.rotateInterval > 0 && time.Since(r.opened) >= r.options.rotateInterval {
+		return true
+	}
+	return false
+}
+
+func (r *rotatingWriter) closeCurrent() {
+	for _, sw := range r.sinkWriters {
+		if sw.isFailed() {
+			continue // its goroutine already closed pr with the error that failed it
+		}
+		sw.pw.Close()
+	}
+	r.wg.Wait()
+}
+
+func (r *rotatingWriter) Close() {
+	r.closeCurrent()
+}
+
+// lineReader adapts an io.Reader to a ReadLine call that returns one line at a time with a nil
+// error (a final line without a trailing newline included), and then an empty line with the error
+// that ended the stream (io.EOF on a clean end) instead of bufio.Scanner's bare false from Scan.
+type lineReader struct {
+	scanner *bufio.Scanner
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{scanner: bufio.NewScanner(r)}
+}
+
+func (l *lineReader) ReadLine() (string, error) {
+	if l.scanner.Scan() {
+		return l.scanner.Text(), nil
+	}
+	if err := l.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
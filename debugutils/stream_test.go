@@ -0,0 +1,107 @@
+package debugutils
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSaved struct {
+	Name    string
+	Content string
+}
+
+// fakeStorageClient is a StorageClient test double that records every object it's asked to save,
+// optionally failing (without reading anything) on saves whose Name contains failFor.
+type fakeStorageClient struct {
+	mu      sync.Mutex
+	saved   []fakeSaved
+	failFor string
+}
+
+func (f *fakeStorageClient) Save(location string, obj *StorageObject) error {
+	if f.failFor != "" && strings.Contains(obj.Name, f.failFor) {
+		return errors.New("boom")
+	}
+	content, _ := ioutil.ReadAll(obj.Resource)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saved = append(f.saved, fakeSaved{Name: obj.Name, Content: string(content)})
+	return nil
+}
+
+func TestRotatingWriter_PerSinkErrorIsolationDoesNotBlockGoodSinks(t *testing.T) {
+	good := &fakeStorageClient{}
+	bad := &fakeStorageClient{failFor: "resource"}
+
+	partCounter := new(int)
+	rot := newRotatingWriter(context.Background(), []StorageClient{bad, good}, "loc", "resource", partCounter, &streamLogsOptions{})
+
+	for i := 0; i < 5; i++ {
+		rot.WriteLine("line")
+	}
+	rot.Close()
+
+	if len(good.saved) != 1 {
+		t.Fatalf("expected the healthy sink to receive its object, got %d saves", len(good.saved))
+	}
+	if strings.Count(good.saved[0].Content, "line\n") != 5 {
+		t.Fatalf("expected healthy sink content to contain every line, got %q", good.saved[0].Content)
+	}
+	if len(bad.saved) != 0 {
+		t.Fatalf("expected the failing sink to never successfully save, got %d saves", len(bad.saved))
+	}
+}
+
+func TestSplitTimestampedLine(t *testing.T) {
+	ts, line := splitTimestampedLine("2021-01-02T15:04:05.123456789Z hello world")
+	if line != "hello world" {
+		t.Fatalf("expected the timestamp prefix to be stripped, got %q", line)
+	}
+	want, _ := time.Parse(time.RFC3339Nano, "2021-01-02T15:04:05.123456789Z")
+	if !ts.Equal(want) {
+		t.Fatalf("expected parsed timestamp %v, got %v", want, ts)
+	}
+}
+
+func TestSplitTimestampedLine_FallsBackOnUnparseablePrefix(t *testing.T) {
+	_, line := splitTimestampedLine("not-a-timestamp hello world")
+	if line != "not-a-timestamp hello world" {
+		t.Fatalf("expected the original line to pass through unchanged, got %q", line)
+	}
+}
+
+func TestRotatingWriter_PartNumberingIsMonotonicAcrossReconnects(t *testing.T) {
+	sink := &fakeStorageClient{}
+	options := &streamLogsOptions{rotateBytes: 1} // rotate before every line after a part's first
+
+	partCounter := new(int)
+
+	// first "connection": writes two lines, the second of which rotates to a new part internally
+	rot := newRotatingWriter(context.Background(), []StorageClient{sink}, "loc", "resource", partCounter, options)
+	rot.WriteLine("one")
+	rot.WriteLine("two")
+	rot.Close()
+
+	// a reconnect must never reuse the last part name it just finalized, same as streamWithRetry
+	*partCounter++
+
+	rot = newRotatingWriter(context.Background(), []StorageClient{sink}, "loc", "resource", partCounter, options)
+	rot.WriteLine("three")
+	rot.Close()
+
+	seen := map[string]bool{}
+	for _, saved := range sink.saved {
+		if seen[saved.Name] {
+			t.Fatalf("part name %q was reused, overwriting a previously saved object", saved.Name)
+		}
+		seen[saved.Name] = true
+	}
+	if len(seen) != len(sink.saved) {
+		t.Fatalf("expected every saved part to have a unique name")
+	}
+}
@@ -0,0 +1,130 @@
+package gcloudutils
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/solo-io/go-utils/contextutils"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// CIProvider identifies which CI system a CIBuildEvent was normalized from.
+type CIProvider string
+
+const (
+	ProviderCloudBuild    CIProvider = "cloud-build"
+	ProviderGitHubActions CIProvider = "github-actions"
+	ProviderGitLab        CIProvider = "gitlab"
+)
+
+// CIBuildEvent is the provider-agnostic shape every CIEventSource normalizes its events into, so
+// downstream release/notification logic only has to be written once regardless of where the build
+// actually ran.
+type CIBuildEvent struct {
+	Provider       CIProvider
+	ProjectID      string
+	BuildID        string
+	Status         string
+	Trigger        string
+	Commit         string
+	InstallationID int64
+	Tags           map[string]string
+}
+
+// CIEventHandler is notified of every CIBuildEvent, regardless of which CIEventSource produced it.
+type CIEventHandler interface {
+	HandleCIEvent(ctx context.Context, event *CIBuildEvent) error
+}
+
+// CIEventRegistry holds the set of handlers registered on a CIEventSubscriber.
+type CIEventRegistry struct {
+	handlers []CIEventHandler
+}
+
+func (r *CIEventRegistry) RegisterHandler(handler CIEventHandler) {
+	r.handlers = append(r.handlers, handler)
+}
+
+// Dispatch fans event out to every registered handler concurrently. Handler errors are logged,
+// not returned, so a failing handler never blocks or fails delivery to the others.
+func (r *CIEventRegistry) Dispatch(ctx context.Context, event *CIBuildEvent) {
+	for _, handler := range r.handlers {
+		handler := handler
+		go func() {
+			if err := handler.HandleCIEvent(ctx, event); err != nil {
+				contextutils.LoggerFrom(ctx).Errorw("error handling CI event",
+					zap.String("provider", string(event.Provider)), zap.String("build_id", event.BuildID), zap.Error(err))
+			}
+		}()
+	}
+}
+
+// CIEventSource is a provider-specific origin of CIBuildEvents.
+type CIEventSource interface {
+	Provider() CIProvider
+}
+
+// PushSource is a CIEventSource that owns a long-running connection (e.g. a pubsub subscription)
+// and pushes normalized events into registry until ctx is cancelled.
+type PushSource interface {
+	CIEventSource
+	Run(ctx context.Context, registry *CIEventRegistry) error
+}
+
+// WebhookSource is a CIEventSource that is driven by an external HTTP server rather than owning
+// its own loop; Handler returns the http.Handler the caller should mount to receive its webhooks.
+type WebhookSource interface {
+	CIEventSource
+	Handler(registry *CIEventRegistry) http.Handler
+}
+
+// CIEventSubscriber fans CI build events in from any number of pluggable sources - Cloud Build via
+// pubsub, GitHub Actions and GitLab via webhook - and normalizes them to CIBuildEvent before
+// notifying registered handlers, so handlers don't need to be duplicated per provider.
+type CIEventSubscriber struct {
+	registry       *CIEventRegistry
+	pushSources    []PushSource
+	webhookSources []WebhookSource
+}
+
+// NewCIEventSubscriber builds a subscriber out of any mix of PushSource and WebhookSource.
+func NewCIEventSubscriber(sources ...CIEventSource) *CIEventSubscriber {
+	cs := &CIEventSubscriber{registry: &CIEventRegistry{}}
+	for _, source := range sources {
+		switch s := source.(type) {
+		case PushSource:
+			cs.pushSources = append(cs.pushSources, s)
+		case WebhookSource:
+			cs.webhookSources = append(cs.webhookSources, s)
+		}
+	}
+	return cs
+}
+
+func (cs *CIEventSubscriber) RegisterHandler(handler CIEventHandler) {
+	cs.registry.RegisterHandler(handler)
+}
+
+// Run blocks every PushSource until ctx is cancelled. WebhookSources are not driven here: mount
+// their Handlers on the caller's own HTTP server via WebhookHandlers.
+func (cs *CIEventSubscriber) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, source := range cs.pushSources {
+		source := source
+		eg.Go(func() error {
+			return source.Run(ctx, cs.registry)
+		})
+	}
+	return eg.Wait()
+}
+
+// WebhookHandlers returns the http.Handler for every registered WebhookSource, keyed by provider,
+// so the caller can mount them on its own mux (e.g. "/webhooks/github-actions").
+func (cs *CIEventSubscriber) WebhookHandlers() map[CIProvider]http.Handler {
+	handlers := make(map[CIProvider]http.Handler, len(cs.webhookSources))
+	for _, source := range cs.webhookSources {
+		handlers[source.Provider()] = source.Handler(cs.registry)
+	}
+	return handlers
+}
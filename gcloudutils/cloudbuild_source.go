@@ -0,0 +1,64 @@
+package gcloudutils
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/cloudbuild/v1"
+)
+
+// CloudBuildSource adapts an existing CloudSubscriber into a PushSource, so Cloud Build keeps
+// working as just one of several pluggable CIEventSubscriber sources.
+type CloudBuildSource struct {
+	sub *CloudSubscriber
+}
+
+func NewCloudBuildSource(sub *CloudSubscriber) *CloudBuildSource {
+	return &CloudBuildSource{sub: sub}
+}
+
+func (s *CloudBuildSource) Provider() CIProvider {
+	return ProviderCloudBuild
+}
+
+func (s *CloudBuildSource) Run(ctx context.Context, registry *CIEventRegistry) error {
+	s.sub.ciRegistry = registry
+	return s.sub.Run(ctx)
+}
+
+// normalizeCloudBuildEvent converts a raw cloudbuild.Build into the provider-agnostic CIBuildEvent
+// shape shared by every CIEventSource.
+func normalizeCloudBuildEvent(build *cloudbuild.Build, installationId int64) *CIBuildEvent {
+	var commit string
+	if build.SourceProvenance != nil && build.SourceProvenance.ResolvedRepoSource != nil {
+		commit = build.SourceProvenance.ResolvedRepoSource.CommitSha
+	}
+	var trigger string
+	if build.BuildTriggerId != "" {
+		trigger = build.BuildTriggerId
+	}
+	return &CIBuildEvent{
+		Provider:       ProviderCloudBuild,
+		ProjectID:      build.ProjectId,
+		BuildID:        build.Id,
+		Status:         build.Status,
+		Trigger:        trigger,
+		Commit:         commit,
+		InstallationID: installationId,
+		Tags:           tagsToMap(build.Tags),
+	}
+}
+
+// tagsToMap parses Cloud Build's "key=value" tag strings into a map, skipping any tag that isn't
+// in that form.
+func tagsToMap(tags []string) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		parts := strings.SplitN(tag, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result
+}
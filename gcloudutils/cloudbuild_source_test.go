@@ -0,0 +1,48 @@
+package gcloudutils
+
+import (
+	"testing"
+
+	"google.golang.org/api/cloudbuild/v1"
+)
+
+func TestTagsToMap(t *testing.T) {
+	tags := tagsToMap([]string{"branch=main", "trigger=nightly", "malformed"})
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 parsed tags, got %d: %v", len(tags), tags)
+	}
+	if tags["branch"] != "main" || tags["trigger"] != "nightly" {
+		t.Fatalf("unexpected parsed tags: %v", tags)
+	}
+}
+
+func TestNormalizeCloudBuildEvent(t *testing.T) {
+	build := &cloudbuild.Build{
+		ProjectId:      "my-project",
+		Id:             "build-123",
+		Status:         "SUCCESS",
+		BuildTriggerId: "trigger-1",
+		Tags:           []string{"branch=main"},
+		SourceProvenance: &cloudbuild.SourceProvenance{
+			ResolvedRepoSource: &cloudbuild.RepoSource{CommitSha: "deadbeef"},
+		},
+	}
+
+	event := normalizeCloudBuildEvent(build, 42)
+
+	if event.Provider != ProviderCloudBuild {
+		t.Errorf("expected provider %s, got %s", ProviderCloudBuild, event.Provider)
+	}
+	if event.ProjectID != "my-project" || event.BuildID != "build-123" || event.Status != "SUCCESS" {
+		t.Errorf("unexpected normalized fields: %+v", event)
+	}
+	if event.Commit != "deadbeef" {
+		t.Errorf("expected commit sha to be resolved from SourceProvenance, got %q", event.Commit)
+	}
+	if event.InstallationID != 42 {
+		t.Errorf("expected installation id to be passed through, got %d", event.InstallationID)
+	}
+	if event.Tags["branch"] != "main" {
+		t.Errorf("expected tags to be parsed, got %v", event.Tags)
+	}
+}
@@ -30,6 +30,9 @@ type CloudSubscriber struct {
 	cloudBuildSub       *pubsub.Subscription
 	cfg                 *botconfig.Config
 	registry            *CloudBuildRegistry
+	// ciRegistry is set by CloudBuildSource.Run when this CloudSubscriber is driven as part of a
+	// CIEventSubscriber, so handleCloudBuildEvent can also normalize and dispatch the generic event.
+	ciRegistry *CIEventRegistry
 }
 
 func NewCloudSubscriber(ctx context.Context, cfg *botconfig.Config, githubClientCreator githubapp.ClientCreator, projectId string, subscriptionId string) (*CloudSubscriber, error) {
@@ -118,6 +121,10 @@ func (cs *CloudSubscriber) handleCloudBuildEvent(ctx context.Context, msg *pubsu
 
 	// handle all post release events
 	HandleCloudBuildEvent(ctx, cs.registry, githubClient, &cbm)
+
+	if cs.ciRegistry != nil {
+		cs.ciRegistry.Dispatch(ctx, normalizeCloudBuildEvent(&cbm, instId))
+	}
 }
 
 func HandleCloudBuildEvent(ctx context.Context, registry *CloudBuildRegistry, client *github.Client, build *cloudbuild.Build) {
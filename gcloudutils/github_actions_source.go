@@ -0,0 +1,75 @@
+package gcloudutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+)
+
+// GitHubActionsSource normalizes GitHub's workflow_run webhook events into CIBuildEvents. It is a
+// githubapp.Handler so signature verification and event-type dispatch are handled by go-githubapp,
+// the same library CloudSubscriber already uses for installation clients.
+type GitHubActionsSource struct {
+	webhookSecret string
+	registry      *CIEventRegistry
+}
+
+// NewGitHubActionsSource builds a GitHubActionsSource that verifies incoming webhooks against
+// webhookSecret, the same secret configured on the GitHub App/webhook.
+func NewGitHubActionsSource(webhookSecret string) *GitHubActionsSource {
+	return &GitHubActionsSource{webhookSecret: webhookSecret}
+}
+
+func (s *GitHubActionsSource) Provider() CIProvider {
+	return ProviderGitHubActions
+}
+
+// Handles satisfies githubapp.Handler; GitHubActionsSource only cares about workflow_run events.
+func (s *GitHubActionsSource) Handles() []string {
+	return []string{"workflow_run"}
+}
+
+// Handle satisfies githubapp.Handler. By the time this is called, go-githubapp's dispatcher has
+// already verified the webhook signature against webhookSecret.
+func (s *GitHubActionsSource) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event github.WorkflowRunEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrapf(err, "unable to unmarshal workflow_run event %s", deliveryID)
+	}
+	s.registry.Dispatch(ctx, normalizeWorkflowRunEvent(&event))
+	return nil
+}
+
+// Handler returns the go-githubapp event dispatcher for this source's webhook route. registry is
+// stashed so Handle, called later by the dispatcher, knows where to publish normalized events.
+func (s *GitHubActionsSource) Handler(registry *CIEventRegistry) http.Handler {
+	s.registry = registry
+	return githubapp.NewEventDispatcher([]githubapp.Handler{s}, s.webhookSecret)
+}
+
+func normalizeWorkflowRunEvent(event *github.WorkflowRunEvent) *CIBuildEvent {
+	run := event.GetWorkflowRun()
+	status := run.GetStatus()
+	if conclusion := run.GetConclusion(); conclusion != "" {
+		status = conclusion
+	}
+	var installationID int64
+	if event.GetInstallation() != nil {
+		installationID = event.GetInstallation().GetID()
+	}
+	return &CIBuildEvent{
+		Provider:       ProviderGitHubActions,
+		ProjectID:      event.GetRepo().GetFullName(),
+		BuildID:        fmt.Sprintf("%d", run.GetID()),
+		Status:         status,
+		Trigger:        run.GetEvent(),
+		Commit:         run.GetHeadSha(),
+		InstallationID: installationID,
+		Tags:           map[string]string{"workflow": run.GetName(), "branch": run.GetHeadBranch()},
+	}
+}
@@ -0,0 +1,49 @@
+package gcloudutils
+
+import (
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestNormalizeWorkflowRunEvent(t *testing.T) {
+	event := &github.WorkflowRunEvent{
+		Repo: &github.Repository{FullName: github.String("acme/widgets")},
+		Installation: &github.Installation{
+			ID: github.Int64(99),
+		},
+		WorkflowRun: &github.WorkflowRun{
+			ID:         github.Int64(555),
+			Status:     github.String("completed"),
+			Conclusion: github.String("success"),
+			Event:      github.String("push"),
+			HeadSha:    github.String("cafebabe"),
+			Name:       github.String("CI"),
+			HeadBranch: github.String("main"),
+		},
+	}
+
+	build := normalizeWorkflowRunEvent(event)
+
+	if build.Provider != ProviderGitHubActions {
+		t.Errorf("expected provider %s, got %s", ProviderGitHubActions, build.Provider)
+	}
+	if build.ProjectID != "acme/widgets" {
+		t.Errorf("unexpected project id: %s", build.ProjectID)
+	}
+	if build.BuildID != "555" {
+		t.Errorf("unexpected build id: %s", build.BuildID)
+	}
+	if build.Status != "success" {
+		t.Errorf("expected conclusion to take priority over status, got %s", build.Status)
+	}
+	if build.Commit != "cafebabe" {
+		t.Errorf("unexpected commit: %s", build.Commit)
+	}
+	if build.InstallationID != 99 {
+		t.Errorf("unexpected installation id: %d", build.InstallationID)
+	}
+	if build.Tags["workflow"] != "CI" || build.Tags["branch"] != "main" {
+		t.Errorf("unexpected tags: %v", build.Tags)
+	}
+}
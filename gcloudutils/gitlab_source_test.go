@@ -0,0 +1,44 @@
+package gcloudutils
+
+import (
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestNormalizePipelineEvent(t *testing.T) {
+	event := &gitlab.PipelineEvent{}
+	event.Project.ID = 7
+	event.ObjectAttributes.ID = 321
+	event.ObjectAttributes.Status = "success"
+	event.ObjectAttributes.Source = "push"
+	event.ObjectAttributes.SHA = "0123456789"
+	event.ObjectAttributes.Ref = "main"
+
+	build := normalizePipelineEvent(event)
+
+	if build.Provider != ProviderGitLab {
+		t.Errorf("expected provider %s, got %s", ProviderGitLab, build.Provider)
+	}
+	if build.ProjectID != "7" || build.BuildID != "321" {
+		t.Errorf("unexpected ids: project=%s build=%s", build.ProjectID, build.BuildID)
+	}
+	if build.Status != "success" || build.Trigger != "push" || build.Commit != "0123456789" {
+		t.Errorf("unexpected normalized fields: %+v", build)
+	}
+	if build.Tags["ref"] != "main" {
+		t.Errorf("unexpected tags: %v", build.Tags)
+	}
+}
+
+func TestConstantTimeEquals(t *testing.T) {
+	if !constantTimeEquals("s3cr3t", "s3cr3t") {
+		t.Error("expected equal strings to compare equal")
+	}
+	if constantTimeEquals("s3cr3t", "wrong") {
+		t.Error("expected different strings to compare unequal")
+	}
+	if constantTimeEquals("s3cr3t", "s3cr3t-longer") {
+		t.Error("expected strings of different length to compare unequal")
+	}
+}
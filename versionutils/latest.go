@@ -0,0 +1,183 @@
+package versionutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	"github.com/solo-io/go-utils/githubutils"
+)
+
+const (
+	defaultCacheTTL = 24 * time.Hour
+	cacheSubDir     = "solo-io/go-utils/versionutils"
+)
+
+// LatestReleaseInfo is the result of CheckLatestRelease.
+type LatestReleaseInfo struct {
+	Current         string
+	Latest          string
+	UpdateAvailable bool
+	ReleaseURL      string
+	PublishedAt     time.Time
+}
+
+type checkLatestReleaseOptions struct {
+	ttl        time.Duration
+	includePre bool
+	cacheDir   string
+}
+
+// CheckLatestReleaseOption configures CheckLatestRelease.
+type CheckLatestReleaseOption func(*checkLatestReleaseOptions)
+
+// WithCacheTTL overrides the default 24h cache TTL.
+func WithCacheTTL(ttl time.Duration) CheckLatestReleaseOption {
+	return func(o *checkLatestReleaseOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithPreReleases opts in to considering pre-releases when determining the latest version.
+func WithPreReleases() CheckLatestReleaseOption {
+	return func(o *checkLatestReleaseOptions) {
+		o.includePre = true
+	}
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time         `json:"fetchedAt"`
+	Info      LatestReleaseInfo `json:"info"`
+}
+
+// CheckLatestRelease compares currentVersion to the latest release tag of owner/repo on GitHub,
+// caching the result on disk under $XDG_CACHE_HOME for opts' TTL (24h by default) so that repeated
+// CLI invocations don't hit GitHub's rate limit. currentVersion may be a non-semver dev build
+// (e.g. "v0.0.0-dev"), in which case UpdateAvailable is always false.
+func CheckLatestRelease(ctx context.Context, owner, repo, currentVersion string, opts ...CheckLatestReleaseOption) (*LatestReleaseInfo, error) {
+	options := &checkLatestReleaseOptions{ttl: defaultCacheTTL}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cachePath, pathErr := cacheFilePath(owner, repo)
+	if pathErr == nil {
+		if cached, ok := readCache(cachePath, options.ttl); ok {
+			cached.Current = currentVersion
+			cached.UpdateAvailable = isUpdateAvailable(currentVersion, cached.Latest)
+			return &cached, nil
+		}
+	}
+
+	release, err := latestRelease(ctx, owner, repo, options.includePre)
+	if err != nil {
+		return nil, err
+	}
+
+	info := LatestReleaseInfo{
+		Current:     currentVersion,
+		Latest:      release.GetTagName(),
+		ReleaseURL:  release.GetHTMLURL(),
+		PublishedAt: release.GetPublishedAt().Time,
+	}
+	info.UpdateAvailable = isUpdateAvailable(currentVersion, info.Latest)
+
+	if pathErr == nil {
+		writeCache(cachePath, info)
+	}
+
+	return &info, nil
+}
+
+// MustCheckLatestReleaseAsync runs CheckLatestRelease in a goroutine and returns a channel that
+// receives the result once it's available. It never sends on error: a failed check (offline, rate
+// limited, etc) is logged to stderr and simply produces no value, so callers can use it to print a
+// best-effort "update available" banner at exit without blocking startup or handling errors.
+func MustCheckLatestReleaseAsync(ctx context.Context, owner, repo, currentVersion string, opts ...CheckLatestReleaseOption) <-chan *LatestReleaseInfo {
+	ch := make(chan *LatestReleaseInfo, 1)
+	go func() {
+		defer close(ch)
+		info, err := CheckLatestRelease(ctx, owner, repo, currentVersion, opts...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: unable to check for a newer release of %s/%s: %v\n", owner, repo, err)
+			return
+		}
+		ch <- info
+	}()
+	return ch
+}
+
+func isUpdateAvailable(current, latest string) bool {
+	if !MatchesRegex(current) {
+		// dev builds (v0.0.0-dev, etc) are never considered "behind" a release
+		return false
+	}
+	greater, err := IsGreaterThanTag(latest, current)
+	if err != nil {
+		return false
+	}
+	return greater
+}
+
+func latestRelease(ctx context.Context, owner, repo string, includePre bool) (*github.RepositoryRelease, error) {
+	client, err := githubutils.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !includePre {
+		release, _, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting latest release for %s/%s", owner, repo)
+		}
+		return release, nil
+	}
+	releases, _, err := client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing releases for %s/%s", owner, repo)
+	}
+	if len(releases) == 0 {
+		return nil, errors.Errorf("no releases found for %s/%s", owner, repo)
+	}
+	return releases[0], nil
+}
+
+func cacheFilePath(owner, repo string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, cacheSubDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s-%s-latest-release.json", owner, repo)), nil
+}
+
+func readCache(path string, ttl time.Duration) (LatestReleaseInfo, bool) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return LatestReleaseInfo{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(bytes, &entry); err != nil {
+		return LatestReleaseInfo{}, false
+	}
+	if time.Since(entry.FetchedAt) > ttl {
+		return LatestReleaseInfo{}, false
+	}
+	return entry.Info, true
+}
+
+func writeCache(path string, info LatestReleaseInfo) {
+	bytes, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Info: info})
+	if err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(path, bytes, 0o644)
+}
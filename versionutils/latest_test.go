@@ -0,0 +1,47 @@
+package versionutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	path, err := cacheFilePath("solo-io", "go-utils")
+	if err != nil {
+		t.Fatalf("unexpected error resolving cache path: %v", err)
+	}
+
+	info := LatestReleaseInfo{Current: "v1.0.0", Latest: "v1.1.0", ReleaseURL: "https://example.com"}
+	writeCache(path, info)
+
+	cached, ok := readCache(path, time.Hour)
+	if !ok {
+		t.Fatal("expected a cache hit immediately after writing")
+	}
+	if cached.Latest != info.Latest || cached.ReleaseURL != info.ReleaseURL {
+		t.Fatalf("expected cached info to round-trip, got %+v", cached)
+	}
+}
+
+func TestReadCache_MissesOnceExpired(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	path, err := cacheFilePath("solo-io", "go-utils")
+	if err != nil {
+		t.Fatalf("unexpected error resolving cache path: %v", err)
+	}
+
+	writeCache(path, LatestReleaseInfo{Latest: "v1.1.0"})
+
+	if _, ok := readCache(path, -time.Second); ok {
+		t.Fatal("expected a cache miss once the entry is older than the TTL")
+	}
+}
+
+func TestReadCache_MissesOnMissingFile(t *testing.T) {
+	if _, ok := readCache("/does/not/exist.json", time.Hour); ok {
+		t.Fatal("expected a cache miss for a file that doesn't exist")
+	}
+}